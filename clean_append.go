@@ -0,0 +1,190 @@
+// Copyright 2013 Julien Schmidt. All rights reserved.
+// Based on the path package, Copyright 2009 The Go Authors.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package httprouter
+
+// 这个包目前只导出路径清理相关的叶子函数，还没有 Router / ServeHTTP /
+// RedirectFixedPath 之类的类型——也就是说下面的 CleanPathAppend /
+// CleanPathBytes 还没有被任何请求处理路径接入，sync.Pool 形式的暂存
+// 缓冲区池也无从谈起。它们是给将来的路由分发代码使用的构件：一旦这个
+// 包里出现了 Router，ServeHTTP 应该从池里取出 []byte、调用
+// CleanPathAppend 写入，再把缓冲区放回池中，从而让
+// RedirectFixedPath / 尾部斜杠重定向不必每个请求都重新分配。
+
+// CleanPathAppend 与 CleanPath 做同样的清理工作，但是把结果追加到
+// 调用方提供的 dst 之后并返回扩展后的切片，而不是分配一个新的 string。
+//
+// 如果 cap(dst)-len(dst) >= len(p)+1，追加过程复用 dst 的底层数组；
+// 否则按需通过 append 扩容。和 CleanPath 一样，只要 p 已经以 "/" 开头
+// 且输出与 p 逐字节相同，就不会提前把字符写入 dst —— 只有在遇到第一处
+// 分叉（多余的斜杠、"." / ".." 段）时才会一次性把之前的字节追加进去，
+// 这样已经规范的路径（未来路由器 ServeHTTP 里最常见的情况）只在最后
+// 整体追加一次，不会逐字节写入。
+func CleanPathAppend(dst []byte, p string) []byte {
+	if p == "" {
+		return append(dst, '/')
+	}
+
+	n := len(p)
+
+	if need := n + 1; cap(dst)-len(dst) < need {
+		grown := make([]byte, len(dst), len(dst)+need)
+		copy(grown, dst)
+		dst = grown
+	}
+
+	start := len(dst)
+	r, w := 1, 1
+
+	// p 不以 '/' 开头时，和 CleanPath 一样没有惰性快路径可走：
+	// 直接写入合成的前导 '/'，后续全部走“已分叉”分支。
+	if p[0] != '/' {
+		r = 0
+		dst = append(dst, '/')
+	}
+
+	trailing := n > 1 && p[n-1] == '/'
+
+	for r < n {
+		switch {
+		case p[r] == '/':
+			r++
+
+		case p[r] == '.' && r+1 == n:
+			trailing = true
+			r++
+
+		case p[r] == '.' && p[r+1] == '/':
+			r += 2
+
+		case p[r] == '.' && p[r+1] == '.' && (r+2 == n || p[r+2] == '/'):
+			r += 3
+
+			if w > 1 {
+				w--
+
+				if len(dst) == start {
+					for w > 1 && p[w] != '/' {
+						w--
+					}
+				} else {
+					for w > 1 && dst[start+w] != '/' {
+						w--
+					}
+				}
+			}
+			if len(dst) > start+w {
+				dst = dst[:start+w]
+			}
+
+		default:
+			if w > 1 {
+				dst = cleanAppByte(dst, p, start, w, '/')
+				w++
+			}
+
+			for r < n && p[r] != '/' {
+				dst = cleanAppByte(dst, p, start, w, p[r])
+				w++
+				r++
+			}
+		}
+	}
+
+	if trailing && w > 1 {
+		dst = cleanAppByte(dst, p, start, w, '/')
+		w++
+	}
+
+	if len(dst) == start {
+		// 还没有分叉：输出与 p[:w] 完全相同，一次性追加即可。
+		return append(dst, p[:w]...)
+	}
+	return dst[:start+w]
+}
+
+// cleanAppByte 是 CleanPathAppend 的惰性写入助手：只要 dst 里还没有为
+// 这次清理写入任何字节，且下一个要写的字节与 p 里对应位置相同，就什么
+// 都不做，从而避免过早追加。
+func cleanAppByte(dst []byte, p string, start, w int, c byte) []byte {
+	if len(dst) == start {
+		if w < len(p) && p[w] == c {
+			return dst
+		}
+		dst = append(dst, p[:w]...)
+	}
+
+	if len(dst) == start+w {
+		return append(dst, c)
+	}
+	dst[start+w] = c
+	return dst
+}
+
+// CleanPathBytes 原地清理 p 并返回清理后的切片。和 CleanPathAppend 不
+// 同，它不需要惰性写入技巧：折叠 "." / ".." 段只会让输出不长于输入
+// （当 p 以 "/" 开头时），所以可以把 p 自身既当读指针又当写指针原地
+// 改写，不做任何分配。只有 p 不以 "/"
+// 开头、需要合成一个比 p 长一个字节的前导 "/" 时，才会退化为分配一个
+// 新的缓冲区。
+func CleanPathBytes(p []byte) []byte {
+	n := len(p)
+
+	if n == 0 {
+		return append(p[:0], '/')
+	}
+
+	if p[0] != '/' {
+		// 输出比输入多一个字节，原地清理已经不可能，只能分配。
+		return CleanPathAppend(make([]byte, 0, n+1), string(p))
+	}
+
+	r, w := 1, 1
+
+	trailing := n > 1 && p[n-1] == '/'
+
+	for r < n {
+		switch {
+		case p[r] == '/':
+			r++
+
+		case p[r] == '.' && r+1 == n:
+			trailing = true
+			r++
+
+		case p[r] == '.' && p[r+1] == '/':
+			r += 2
+
+		case p[r] == '.' && p[r+1] == '.' && (r+2 == n || p[r+2] == '/'):
+			r += 3
+
+			if w > 1 {
+				w--
+				for w > 1 && p[w] != '/' {
+					w--
+				}
+			}
+
+		default:
+			if w > 1 {
+				p[w] = '/'
+				w++
+			}
+
+			for r < n && p[r] != '/' {
+				p[w] = p[r]
+				w++
+				r++
+			}
+		}
+	}
+
+	if trailing && w > 1 {
+		p[w] = '/'
+		w++
+	}
+
+	return p[:w]
+}
@@ -0,0 +1,48 @@
+// Copyright 2013 Julien Schmidt. All rights reserved.
+// Based on the path package, Copyright 2009 The Go Authors.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package httprouter
+
+import "testing"
+
+func TestCleanPathAppend(t *testing.T) {
+	tests := []string{
+		"",
+		"/",
+		"a",
+		"/a/./b/../c/",
+		"/../a",
+		"//a//b//",
+		"/a/../../b",
+	}
+
+	for _, p := range tests {
+		want := CleanPath(p)
+		if got := string(CleanPathAppend(nil, p)); got != want {
+			t.Errorf("CleanPathAppend(nil, %q) = %q, want %q (CleanPath)", p, got, want)
+		}
+
+		// 追加到一个非空的已有内容之后，前缀必须保持不变。
+		dst := CleanPathAppend([]byte("PREFIX"), p)
+		if got := string(dst); got != "PREFIX"+want {
+			t.Errorf("CleanPathAppend([]byte(%q), %q) = %q, want %q", "PREFIX", p, got, "PREFIX"+want)
+		}
+	}
+}
+
+func TestCleanPathBytes(t *testing.T) {
+	tests := []string{
+		"",
+		"/a/./b/../c/",
+		"/../a",
+	}
+
+	for _, p := range tests {
+		want := CleanPath(p)
+		if got := string(CleanPathBytes([]byte(p))); got != want {
+			t.Errorf("CleanPathBytes(%q) = %q, want %q", p, got, want)
+		}
+	}
+}
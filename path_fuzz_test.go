@@ -0,0 +1,81 @@
+// Copyright 2013 Julien Schmidt. All rights reserved.
+// Based on the path package, Copyright 2009 The Go Authors.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package httprouter
+
+import (
+	"path"
+	"strings"
+	"testing"
+)
+
+func FuzzCleanPath(f *testing.F) {
+	seeds := []string{
+		"",
+		"/",
+		"a",
+		"/..//x",
+		"/./",
+		"/a/./b/../c/",
+		"//",
+		"/../",
+		"/a//b",
+		"/a/../../b",
+		strings.Repeat("/a", 100) + "/./b", // 200 字节左右，走堆上分配的路径
+	}
+	for _, s := range seeds {
+		f.Add(s)
+	}
+
+	f.Fuzz(func(t *testing.T, p string) {
+		got := CleanPath(p)
+
+		// 不变量：结果总是以 "/" 开头。
+		if len(got) == 0 || got[0] != '/' {
+			t.Fatalf("CleanPath(%q) = %q, want a result starting with '/'", p, got)
+		}
+
+		// 不变量：结果不包含连续的 "//"。
+		if strings.Contains(got, "//") {
+			t.Fatalf("CleanPath(%q) = %q, contains \"//\"", p, got)
+		}
+
+		// 不变量：结果不包含 "." 或 ".." 段。
+		for _, seg := range strings.Split(got, "/") {
+			if seg == "." || seg == ".." {
+				t.Fatalf("CleanPath(%q) = %q, contains %q segment", p, got, seg)
+			}
+		}
+
+		// 不变量：结果中的每个字节都必须出现在原始输入中，'/' 除外
+		// （规范化过程本身会补全开头和分隔用的 '/'）。
+		for i := 0; i < len(got); i++ {
+			if got[i] == '/' {
+				continue
+			}
+			if strings.IndexByte(p, got[i]) == -1 {
+				t.Fatalf("CleanPath(%q) = %q, contains byte %q not present in input", p, got, got[i])
+			}
+		}
+
+		// 幂等性：再清理一次不应该有任何变化。
+		if again := CleanPath(got); again != got {
+			t.Fatalf("CleanPath(%q) = %q, not idempotent: CleanPath(%q) = %q", p, got, got, again)
+		}
+
+		// 对照 path.Clean：输入不含连续斜杠时，二者应该一致
+		// （path.Clean 不保留尾部斜杠，因此单独比较去掉尾部斜杠后的结果）。
+		if !strings.Contains(p, "//") {
+			want := path.Clean("/" + p)
+			gotNoTrailing := strings.TrimSuffix(got, "/")
+			if gotNoTrailing == "" {
+				gotNoTrailing = "/"
+			}
+			if gotNoTrailing != want {
+				t.Fatalf("CleanPath(%q) = %q, path.Clean(\"/\"+%q) = %q", p, got, p, want)
+			}
+		}
+	})
+}
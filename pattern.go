@@ -0,0 +1,222 @@
+// Copyright 2013 Julien Schmidt. All rights reserved.
+// Based on the path package, Copyright 2009 The Go Authors.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package httprouter
+
+import (
+	"errors"
+	"strings"
+)
+
+// Param 是一个路径参数，即由名称和值组成的键值对。
+type Param struct {
+	Key   string
+	Value string
+}
+
+// Params 是一组按匹配顺序排列的路径参数。
+type Params []Param
+
+// ByName 返回第一个名为 name 的参数的值。
+// 如果没有找到这样的参数，返回空字符串。
+func (ps Params) ByName(name string) string {
+	for _, p := range ps {
+		if p.Key == name {
+			return p.Value
+		}
+	}
+	return ""
+}
+
+// SegmentKind 描述了模式中一个段的种类。
+type SegmentKind int
+
+const (
+	// SegmentLiteral 是一个字面量段，例如 "users"。
+	SegmentLiteral SegmentKind = iota
+	// SegmentParam 是一个具名参数段，例如 ":id"。
+	SegmentParam
+	// SegmentCatchAll 是一个通配段，例如 "*rest"，只允许出现在模式末尾。
+	SegmentCatchAll
+)
+
+// Segment 是模式中的一个路径段。
+type Segment struct {
+	Kind SegmentKind
+
+	// Literal 在 Kind 为 SegmentLiteral 时保存段的原始内容。
+	Literal string
+
+	// Name 在 Kind 为 SegmentParam 或 SegmentCatchAll 时保存参数名
+	// （不包含前导的 ":" 或 "*"）。
+	Name string
+}
+
+// String 返回 seg 在模式中的原始写法。
+func (seg Segment) String() string {
+	switch seg.Kind {
+	case SegmentParam:
+		return ":" + seg.Name
+	case SegmentCatchAll:
+		return "*" + seg.Name
+	default:
+		return seg.Literal
+	}
+}
+
+// SplitPattern 将一个形如 "/users/:id/posts/*rest" 的路由模式拆分成
+// 结构化的段列表。"." / ".." 折叠直接交给 CleanPath 处理：":name" /
+// "*name" 段总是以 ':' 或 '*' 开头，永远不会等于 "." 或 ".."，所以
+// CleanPath 会把它们当作普通字面量段原样复制，不需要额外的占位替换
+// 就能安全地与真正的字面量段一起折叠。
+//
+// 只有紧跟在模式末尾的段才允许是 SegmentCatchAll；出现在中间的
+// "*catchall" 会被当作一个以字面量 "*" 开头的普通字面量段返回 —— 由
+// JoinPattern 负责在拼接时拒绝这种非法组合。
+//
+// 一个裸的 ":"（没有名字）总是被当成 Name 为空字符串的 SegmentParam，
+// 而不是字面量 ":" ——这样 JoinPattern 会明确报告 ErrEmptyParamName，
+// 而不是把它和字面量里出现 ':' / '*' 的情况混为一谈。末尾裸的 "*" 同理
+// 被当成 Name 为空字符串的 SegmentCatchAll。
+func SplitPattern(pattern string) []Segment {
+	if pattern == "" {
+		return nil
+	}
+
+	clean := CleanPath(pattern)
+
+	parts := strings.Split(strings.Trim(clean, "/"), "/")
+	segs := make([]Segment, 0, len(parts))
+
+	for i, part := range parts {
+		switch {
+		case part == "":
+			continue
+		case part[0] == ':':
+			segs = append(segs, Segment{Kind: SegmentParam, Name: part[1:]})
+		case part[0] == '*' && i == len(parts)-1:
+			segs = append(segs, Segment{Kind: SegmentCatchAll, Name: part[1:]})
+		default:
+			segs = append(segs, Segment{Kind: SegmentLiteral, Literal: part})
+		}
+	}
+
+	return segs
+}
+
+var (
+	// ErrCatchAllNotLast 在 *catchall 段出现在模式中间而不是末尾时返回。
+	ErrCatchAllNotLast = errors.New("httprouter: catch-all segment must be the last segment")
+	// ErrMultipleParamsInSegment 在一个段内出现多于一个参数标记时返回。
+	ErrMultipleParamsInSegment = errors.New("httprouter: only one parameter is allowed per segment")
+	// ErrEmptyParamName 在 ":" 或 "*" 后面没有跟名称时返回。
+	ErrEmptyParamName = errors.New("httprouter: parameter name must not be empty")
+)
+
+// JoinPattern 把一组段拼接成模式字符串，拼接前会做 trie 所要求的校验：
+// *catchall 只能是最后一个段，且每个段只能携带一个参数标记。
+func JoinPattern(segs []Segment) (string, error) {
+	var b strings.Builder
+
+	for i, seg := range segs {
+		switch seg.Kind {
+		case SegmentCatchAll:
+			if i != len(segs)-1 {
+				return "", ErrCatchAllNotLast
+			}
+			if seg.Name == "" {
+				return "", ErrEmptyParamName
+			}
+		case SegmentParam:
+			if seg.Name == "" {
+				return "", ErrEmptyParamName
+			}
+			if strings.ContainsAny(seg.Name, ":*") {
+				return "", ErrMultipleParamsInSegment
+			}
+		case SegmentLiteral:
+			if strings.ContainsAny(seg.Literal, ":*") {
+				return "", ErrMultipleParamsInSegment
+			}
+		}
+
+		b.WriteByte('/')
+		b.WriteString(seg.String())
+	}
+
+	if b.Len() == 0 {
+		return "/", nil
+	}
+	return b.String(), nil
+}
+
+// DirPattern 返回 pattern 中除最后一个段以外的所有段组成的模式，
+// 类似 path.Dir，但不会把 ":name" / "*name" 段拆散。
+func DirPattern(pattern string) string {
+	segs := SplitPattern(pattern)
+	if len(segs) <= 1 {
+		return "/"
+	}
+	dir, err := JoinPattern(segs[:len(segs)-1])
+	if err != nil {
+		return "/"
+	}
+	return dir
+}
+
+// BasePattern 返回 pattern 的最后一个段的原始写法，
+// 类似 path.Base，但不会把 ":name" / "*name" 段拆散。
+func BasePattern(pattern string) string {
+	segs := SplitPattern(pattern)
+	if len(segs) == 0 {
+		return "/"
+	}
+	return segs[len(segs)-1].String()
+}
+
+// MatchPattern 将 path 与 pattern 匹配，返回匹配到的参数以及是否匹配
+// 成功。它不会构建前缀树，适用于测试以及需要在运行时内省路由的中间件。
+func MatchPattern(pattern, path string) (Params, bool) {
+	segs := SplitPattern(pattern)
+
+	clean := CleanPath(path)
+	pathParts := strings.Split(strings.Trim(clean, "/"), "/")
+	if len(pathParts) == 1 && pathParts[0] == "" {
+		pathParts = nil
+	}
+
+	var params Params
+
+	for i, seg := range segs {
+		switch seg.Kind {
+		case SegmentCatchAll:
+			if i > len(pathParts) {
+				return nil, false
+			}
+			rest := strings.Join(pathParts[i:], "/")
+			params = append(params, Param{Key: seg.Name, Value: "/" + rest})
+			return params, true
+
+		case SegmentParam:
+			if i >= len(pathParts) || pathParts[i] == "" {
+				return nil, false
+			}
+			params = append(params, Param{Key: seg.Name, Value: pathParts[i]})
+
+		default:
+			if i >= len(pathParts) || pathParts[i] != seg.Literal {
+				return nil, false
+			}
+		}
+	}
+
+	if len(segs) == 0 || segs[len(segs)-1].Kind != SegmentCatchAll {
+		if len(pathParts) != len(segs) {
+			return nil, false
+		}
+	}
+
+	return params, true
+}
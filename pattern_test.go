@@ -0,0 +1,185 @@
+// Copyright 2013 Julien Schmidt. All rights reserved.
+// Based on the path package, Copyright 2009 The Go Authors.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package httprouter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    []Segment
+	}{
+		{
+			"/users/:id/posts/*rest",
+			[]Segment{
+				{Kind: SegmentLiteral, Literal: "users"},
+				{Kind: SegmentParam, Name: "id"},
+				{Kind: SegmentLiteral, Literal: "posts"},
+				{Kind: SegmentCatchAll, Name: "rest"},
+			},
+		},
+		{
+			// 字面量段 "_" 原样作为字面量段返回。
+			"/a/_/:id",
+			[]Segment{
+				{Kind: SegmentLiteral, Literal: "a"},
+				{Kind: SegmentLiteral, Literal: "_"},
+				{Kind: SegmentParam, Name: "id"},
+			},
+		},
+		{
+			// "*catchall" 出现在中间时被当作普通字面量。
+			"/a/*mid/b",
+			[]Segment{
+				{Kind: SegmentLiteral, Literal: "a"},
+				{Kind: SegmentLiteral, Literal: "*mid"},
+				{Kind: SegmentLiteral, Literal: "b"},
+			},
+		},
+		{
+			// "." 被折叠；".." 消去它前面紧邻的 ":id" 段。
+			"/a/./:id/../b",
+			[]Segment{
+				{Kind: SegmentLiteral, Literal: "a"},
+				{Kind: SegmentLiteral, Literal: "b"},
+			},
+		},
+		{
+			// 裸的 ":" / 末尾裸的 "*" 是 Name 为空字符串的参数/通配段，
+			// 而不是字面量 —— 这样 JoinPattern 能报出 ErrEmptyParamName，
+			// 而不是和字面量里出现 ':'/'*' 的情况混在一起报
+			// ErrMultipleParamsInSegment。
+			"/a/:/b",
+			[]Segment{
+				{Kind: SegmentLiteral, Literal: "a"},
+				{Kind: SegmentParam, Name: ""},
+				{Kind: SegmentLiteral, Literal: "b"},
+			},
+		},
+		{
+			"/a/*",
+			[]Segment{
+				{Kind: SegmentLiteral, Literal: "a"},
+				{Kind: SegmentCatchAll, Name: ""},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		got := SplitPattern(tt.pattern)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SplitPattern(%q) = %+v, want %+v", tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func TestJoinPattern(t *testing.T) {
+	segs := []Segment{
+		{Kind: SegmentLiteral, Literal: "users"},
+		{Kind: SegmentParam, Name: "id"},
+		{Kind: SegmentCatchAll, Name: "rest"},
+	}
+	got, err := JoinPattern(segs)
+	if err != nil {
+		t.Fatalf("JoinPattern() error = %v", err)
+	}
+	if want := "/users/:id/*rest"; got != want {
+		t.Errorf("JoinPattern() = %q, want %q", got, want)
+	}
+
+	// catch-all 不在末尾必须报错。
+	_, err = JoinPattern([]Segment{
+		{Kind: SegmentCatchAll, Name: "rest"},
+		{Kind: SegmentLiteral, Literal: "trailing"},
+	})
+	if err != ErrCatchAllNotLast {
+		t.Errorf("JoinPattern() error = %v, want %v", err, ErrCatchAllNotLast)
+	}
+
+	// 裸的 ":"（SplitPattern 产出 Name 为空字符串的 SegmentParam）必须
+	// 报 ErrEmptyParamName，而不是被当成字面量里出现 ':' 的情况。
+	_, err = JoinPattern(SplitPattern("/a/:/b"))
+	if err != ErrEmptyParamName {
+		t.Errorf("JoinPattern(SplitPattern(%q)) error = %v, want %v", "/a/:/b", err, ErrEmptyParamName)
+	}
+
+	// 末尾裸的 "*" 同理必须报 ErrEmptyParamName。
+	_, err = JoinPattern(SplitPattern("/a/*"))
+	if err != ErrEmptyParamName {
+		t.Errorf("JoinPattern(SplitPattern(%q)) error = %v, want %v", "/a/*", err, ErrEmptyParamName)
+	}
+
+	// 字面量段里出现 ':'/'*' 必须报 ErrMultipleParamsInSegment
+	// （区别于上面两种“空参数名”的情况）。
+	_, err = JoinPattern([]Segment{
+		{Kind: SegmentLiteral, Literal: "a:b"},
+	})
+	if err != ErrMultipleParamsInSegment {
+		t.Errorf("JoinPattern() error = %v, want %v", err, ErrMultipleParamsInSegment)
+	}
+	_, err = JoinPattern([]Segment{
+		{Kind: SegmentParam, Name: "a:b"},
+	})
+	if err != ErrMultipleParamsInSegment {
+		t.Errorf("JoinPattern() error = %v, want %v", err, ErrMultipleParamsInSegment)
+	}
+
+	// 往返：SplitPattern 后 JoinPattern 应该得到相同的模式。
+	pattern := "/users/:id/posts/*rest"
+	joined, err := JoinPattern(SplitPattern(pattern))
+	if err != nil {
+		t.Fatalf("JoinPattern(SplitPattern(%q)) error = %v", pattern, err)
+	}
+	if joined != pattern {
+		t.Errorf("JoinPattern(SplitPattern(%q)) = %q, want %q", pattern, joined, pattern)
+	}
+}
+
+func TestMatchPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    Params
+		wantOK  bool
+	}{
+		{"/users/:id", "/users/42", Params{{Key: "id", Value: "42"}}, true},
+		{"/users/:id", "/users/42/", Params{{Key: "id", Value: "42"}}, true},
+		{"/users/:id", "/users", nil, false},
+		{"/a/_/:id", "/a/_/7", Params{{Key: "id", Value: "7"}}, true},
+		{
+			"/users/:id/posts/*rest",
+			"/users/42/posts/a/b",
+			Params{{Key: "id", Value: "42"}, {Key: "rest", Value: "/a/b"}},
+			true,
+		},
+		{"/static/*rest", "/static", Params{{Key: "rest", Value: "/"}}, true},
+		{"/users/:id", "/other/42", nil, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := MatchPattern(tt.pattern, tt.path)
+		if ok != tt.wantOK {
+			t.Errorf("MatchPattern(%q, %q) ok = %v, want %v", tt.pattern, tt.path, ok, tt.wantOK)
+			continue
+		}
+		if ok && !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("MatchPattern(%q, %q) = %+v, want %+v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestDirAndBasePattern(t *testing.T) {
+	pattern := "/users/:id/posts/*rest"
+	if got, want := DirPattern(pattern), "/users/:id/posts"; got != want {
+		t.Errorf("DirPattern(%q) = %q, want %q", pattern, got, want)
+	}
+	if got, want := BasePattern(pattern), "*rest"; got != want {
+		t.Errorf("BasePattern(%q) = %q, want %q", pattern, got, want)
+	}
+}
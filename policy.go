@@ -0,0 +1,152 @@
+// Copyright 2013 Julien Schmidt. All rights reserved.
+// Based on the path package, Copyright 2009 The Go Authors.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package httprouter
+
+import "strings"
+
+// 这个包目前没有 Router、trie 或重定向逻辑，所以 PathPolicy 只能做到
+// "产出规范形式"：它无法区分"用规范形式直接匹配"和"按规范形式匹配但
+// 对不一致的请求发 301/308 重定向"，因为后者是路由器在查找失败之后
+// 才会做的事。下面的 TrailingSlashRedirectStrip / RedirectAdd 和
+// CaseLowercaseRedirect / CaseLowercaseMatch 仍然作为独立的值导出，
+// 这样将来接入 Router 时可以在它们之间做出真正的行为区分；在那之前，
+// CleanPathWithPolicy 对同一组"目标形式"相同的策略值返回相同的结果。
+
+// TrailingSlashMode 控制 CleanPathWithPolicy 如何处理路径末尾的 "/"。
+type TrailingSlashMode int
+
+const (
+	// TrailingSlashPreserve 保留路径原有的尾部斜杠状态，这是 CleanPath
+	// 历来的行为。
+	TrailingSlashPreserve TrailingSlashMode = iota
+	// TrailingSlashStrip 总是去掉尾部斜杠（根路径 "/" 除外），并且只用
+	// 去掉尾部斜杠之后的形式匹配——带尾部斜杠的请求应当被视为不匹配。
+	TrailingSlashStrip
+	// TrailingSlashRequire 总是加上尾部斜杠，并且只用加上尾部斜杠之后
+	// 的形式匹配——不带尾部斜杠的请求应当被视为不匹配。
+	TrailingSlashRequire
+	// TrailingSlashRedirectStrip 产出与 TrailingSlashStrip 相同的规范
+	// 形式，但意图是：带尾部斜杠的请求仍然匹配，只是应当被重定向到
+	// 去掉尾部斜杠之后的规范形式。是否真正发出重定向由 Router 决定，
+	// 这里没有 Router 可以区分这一点。
+	TrailingSlashRedirectStrip
+	// TrailingSlashRedirectAdd 产出与 TrailingSlashRequire 相同的规范
+	// 形式，但意图是：不带尾部斜杠的请求仍然匹配，只是应当被重定向到
+	// 加上尾部斜杠之后的规范形式。是否真正发出重定向由 Router 决定，
+	// 这里没有 Router 可以区分这一点。
+	TrailingSlashRedirectAdd
+)
+
+// CaseMode 控制 CleanPathWithPolicy 如何处理路径的大小写。
+type CaseMode int
+
+const (
+	// CaseSensitive 保留路径原有的大小写，这是 CleanPath 历来的行为。
+	CaseSensitive CaseMode = iota
+	// CaseLowercaseMatch 把路径统一转换成小写用于匹配，但意图是不对
+	// 原始大小写的请求发重定向——只有 Router 的查找逻辑能做到这一点，
+	// CleanPathWithPolicy 只能返回小写后的规范形式。
+	CaseLowercaseMatch
+	// CaseLowercaseRedirect 把路径统一转换成小写，并且意图是对非小写
+	// 形式的请求发 301/308 重定向到小写规范形式。和
+	// CaseLowercaseMatch 一样，这里没有 Router 可以真正区分二者。
+	CaseLowercaseRedirect
+)
+
+// PathPolicy 描述了一套路径规范化规则：尾部斜杠怎么处理、大小写怎么
+// 处理，以及是否把连续的多个 "/" 合并成一个。CleanPath 本身只实现了
+// "保留尾部斜杠 + 大小写敏感 + 合并斜杠" 这一种策略；CleanPathWithPolicy
+// 在此基础上按 Policy 做进一步规范化，使匹配路由和生成重定向目标时可以
+// 共用同一份、可配置的规范形式。
+type PathPolicy struct {
+	TrailingSlash TrailingSlashMode
+	Case          CaseMode
+	// MergeSlashes 为 false 时，连续的多个 "/" 会被原样保留，
+	// 不会像 CleanPath 那样折叠成一个；"." / ".." 段仍然照常折叠。
+	MergeSlashes bool
+}
+
+// DefaultPathPolicy 是包级别的默认策略，等价于 CleanPath 历来的行为：
+// 保留尾部斜杠、大小写敏感、合并多余的斜杠。CleanPathWithPolicy(p, nil)
+// 等价于 CleanPathWithPolicy(p, &DefaultPathPolicy)。
+var DefaultPathPolicy = PathPolicy{
+	TrailingSlash: TrailingSlashPreserve,
+	Case:          CaseSensitive,
+	MergeSlashes:  true,
+}
+
+// CleanPathWithPolicy 是 CleanPath 的可配置版本：先折叠 "." / ".." 段
+// （按 policy.MergeSlashes 决定是否同时合并多余的斜杠），然后按 policy
+// 调整尾部斜杠和大小写。policy 为 nil 时等价于 DefaultPathPolicy，此时
+// 的行为与 CleanPath 完全一致，从而保持向后兼容。
+func CleanPathWithPolicy(p string, policy *PathPolicy) string {
+	if policy == nil {
+		policy = &DefaultPathPolicy
+	}
+
+	var clean string
+	if policy.MergeSlashes {
+		clean = CleanPath(p)
+	} else {
+		clean = cleanDotsKeepSlashes(p)
+	}
+
+	switch policy.TrailingSlash {
+	case TrailingSlashStrip, TrailingSlashRedirectStrip:
+		if len(clean) > 1 && clean[len(clean)-1] == '/' {
+			clean = clean[:len(clean)-1]
+		}
+	case TrailingSlashRequire, TrailingSlashRedirectAdd:
+		if clean[len(clean)-1] != '/' {
+			clean += "/"
+		}
+	}
+
+	switch policy.Case {
+	case CaseLowercaseMatch, CaseLowercaseRedirect:
+		clean = strings.ToLower(clean)
+	}
+
+	return clean
+}
+
+// cleanDotsKeepSlashes 折叠 "." / ".." 段，但不合并连续的 "/"——与
+// CleanPath 不同，一串连续的斜杠里的每一个空段都会被原样保留下来。
+func cleanDotsKeepSlashes(p string) string {
+	if p == "" {
+		return "/"
+	}
+
+	leading := p[0] == '/'
+	raw := strings.Split(p, "/")
+
+	kept := make([]string, 0, len(raw))
+	for _, seg := range raw {
+		switch seg {
+		case ".":
+			// 当前目录段，直接丢弃。
+		case "..":
+			// 父目录段：去掉最近保留下来的一个非空段。
+			for i := len(kept) - 1; i >= 0; i-- {
+				if kept[i] != "" {
+					kept = append(kept[:i], kept[i+1:]...)
+					break
+				}
+			}
+		default:
+			kept = append(kept, seg)
+		}
+	}
+
+	out := strings.Join(kept, "/")
+	if !leading && (len(out) == 0 || out[0] != '/') {
+		out = "/" + out
+	}
+	if out == "" {
+		out = "/"
+	}
+	return out
+}
@@ -0,0 +1,82 @@
+// Copyright 2013 Julien Schmidt. All rights reserved.
+// Based on the path package, Copyright 2009 The Go Authors.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package httprouter
+
+import "testing"
+
+func TestCleanPathWithPolicyDefault(t *testing.T) {
+	tests := []string{"/a/./b/../c/", "a", "/a//b"}
+	for _, p := range tests {
+		want := CleanPath(p)
+		if got := CleanPathWithPolicy(p, nil); got != want {
+			t.Errorf("CleanPathWithPolicy(%q, nil) = %q, want %q", p, got, want)
+		}
+		if got := CleanPathWithPolicy(p, &DefaultPathPolicy); got != want {
+			t.Errorf("CleanPathWithPolicy(%q, &DefaultPathPolicy) = %q, want %q", p, got, want)
+		}
+	}
+}
+
+func TestCleanPathWithPolicyTrailingSlash(t *testing.T) {
+	tests := []struct {
+		mode TrailingSlashMode
+		path string
+		want string
+	}{
+		{TrailingSlashStrip, "/a/b/", "/a/b"},
+		{TrailingSlashStrip, "/", "/"},
+		{TrailingSlashRequire, "/a/b", "/a/b/"},
+		{TrailingSlashRedirectStrip, "/a/b/", "/a/b"},
+		{TrailingSlashRedirectAdd, "/a/b", "/a/b/"},
+		{TrailingSlashPreserve, "/a/b/", "/a/b/"},
+	}
+
+	for _, tt := range tests {
+		policy := &PathPolicy{TrailingSlash: tt.mode, MergeSlashes: true}
+		if got := CleanPathWithPolicy(tt.path, policy); got != tt.want {
+			t.Errorf("CleanPathWithPolicy(%q, %+v) = %q, want %q", tt.path, policy, got, tt.want)
+		}
+	}
+}
+
+func TestCleanPathWithPolicyCase(t *testing.T) {
+	tests := []struct {
+		mode CaseMode
+		path string
+		want string
+	}{
+		{CaseSensitive, "/Foo/Bar", "/Foo/Bar"},
+		{CaseLowercaseMatch, "/Foo/Bar", "/foo/bar"},
+		{CaseLowercaseRedirect, "/Foo/Bar", "/foo/bar"},
+	}
+
+	for _, tt := range tests {
+		policy := &PathPolicy{Case: tt.mode, MergeSlashes: true}
+		if got := CleanPathWithPolicy(tt.path, policy); got != tt.want {
+			t.Errorf("CleanPathWithPolicy(%q, %+v) = %q, want %q", tt.path, policy, got, tt.want)
+		}
+	}
+}
+
+func TestCleanPathWithPolicyMergeSlashes(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/a//b", "/a//b"},
+		{"/a/./b", "/a/b"},
+		{"/a/../b", "/b"},
+		{"", "/"},
+		{"/", "/"},
+	}
+
+	policy := &PathPolicy{MergeSlashes: false}
+	for _, tt := range tests {
+		if got := CleanPathWithPolicy(tt.path, policy); got != tt.want {
+			t.Errorf("CleanPathWithPolicy(%q, MergeSlashes:false) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
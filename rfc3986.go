@@ -0,0 +1,139 @@
+// Copyright 2013 Julien Schmidt. All rights reserved.
+// Based on the path package, Copyright 2009 The Go Authors.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package httprouter
+
+// CleanPathRFC3986 是 CleanPath 的严格版本，在折叠 . 和 .. 段之前，
+// 先按照 RFC 3986 §6.2.2 对路径做语法层面的规范化：
+//
+//   - 对未保留字符（ALPHA / DIGIT / "-" / "." / "_" / "~"）的
+//     百分号编码进行解码；
+//   - 其余百分号编码的十六进制数字统一大写（"%2f" -> "%2F"）；
+//   - 保留字符及其百分号编码形式原样保留，"%2F" 不会被解码为 "/"。
+//
+// 由于 "%2E" 解码后得到的 "." 与字面量 "." 无法区分，解码后的未保留字节
+// 会被当作普通路径名元素参与后续的 . / .. 折叠（这与浏览器行为一致）；
+// 而 "%2F" 由于未被解码，不会被当作路径分隔符。
+//
+// 规范化之后的结果会交给 CleanPath 做最终的路径折叠，因此两者共享
+// 同一套栈缓冲区 / 惰性写入策略：如果 p 中不包含任何百分号编码，
+// normalizeEscapes 会直接返回 p 本身而不做任何分配。
+func CleanPathRFC3986(p string) string {
+	return CleanPath(normalizeEscapes(p))
+}
+
+// normalizeEscapes 对 p 中的百分号编码做 RFC 3986 规范化，返回规范化
+// 后的字符串。如果 p 中没有 '%'，或者所有百分号编码都已经是规范形式
+// （大写十六进制、且不是未保留字符的编码），则直接返回 p，不分配内存。
+func normalizeEscapes(p string) string {
+	const stackBufSize = 128
+
+	n := len(p)
+
+	// 快速路径：没有百分号编码，无需规范化。
+	firstPct := -1
+	for i := 0; i < n; i++ {
+		if p[i] == '%' {
+			firstPct = i
+			break
+		}
+	}
+	if firstPct == -1 {
+		return p
+	}
+
+	buf := make([]byte, 0, stackBufSize)
+	w := 0
+
+	for r := 0; r < n; {
+		if p[r] != '%' || r+2 >= n || !isHex(p[r+1]) || !isHex(p[r+2]) {
+			escAppBytes(&buf, p, w, p[r])
+			w++
+			r++
+			continue
+		}
+
+		hi, lo := p[r+1], p[r+2]
+		c := hexVal(hi)<<4 | hexVal(lo)
+
+		if isUnreserved(c) {
+			// 未保留字符：解码为字面字节。
+			escAppBytes(&buf, p, w, c)
+			w++
+		} else {
+			// 保留或其他字符：保留编码形式，但十六进制数字大写。
+			escAppBytes(&buf, p, w, '%')
+			w++
+			escAppBytes(&buf, p, w, upperHex(hi))
+			w++
+			escAppBytes(&buf, p, w, upperHex(lo))
+			w++
+		}
+		r += 3
+	}
+
+	if len(buf) == 0 {
+		return p[:w]
+	}
+	return string(buf[:w])
+}
+
+// escAppBytes 与 bufApp 类似：只要输出到目前为止与 p 的对应前缀逐字节
+// 相同，就不分配缓冲区；一旦出现差异，才按需分配并回填之前的字节。
+func escAppBytes(buf *[]byte, p string, w int, c byte) {
+	b := *buf
+	if len(b) == 0 {
+		if w < len(p) && p[w] == c {
+			return
+		}
+
+		const stackBufSize = 128
+		// 规范化只会把 "%XX" 解码成一个字节或原样保留，输出不会比 p
+		// 长，因此按 len(p)（至少 stackBufSize）分配就足够了。
+		need := len(p)
+		if need < stackBufSize {
+			need = stackBufSize
+		}
+		*buf = make([]byte, w, need)
+		copy(*buf, p[:w])
+		b = *buf
+	}
+	*buf = append(b, c)
+}
+
+func isHex(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+func hexVal(c byte) byte {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0'
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10
+	default: // 'A' <= c && c <= 'F'
+		return c - 'A' + 10
+	}
+}
+
+func upperHex(c byte) byte {
+	if 'a' <= c && c <= 'f' {
+		return c - 'a' + 'A'
+	}
+	return c
+}
+
+// isUnreserved 报告 c 是否是 RFC 3986 §2.3 定义的未保留字符：
+// ALPHA / DIGIT / "-" / "." / "_" / "~"。
+func isUnreserved(c byte) bool {
+	switch {
+	case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9':
+		return true
+	case c == '-' || c == '.' || c == '_' || c == '~':
+		return true
+	default:
+		return false
+	}
+}
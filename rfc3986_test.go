@@ -0,0 +1,66 @@
+// Copyright 2013 Julien Schmidt. All rights reserved.
+// Based on the path package, Copyright 2009 The Go Authors.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the LICENSE file.
+
+package httprouter
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestCleanPathRFC3986(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"", "/"},
+		{"/", "/"},
+		{"/foo/%7ebar", "/foo/~bar"},
+		{"/foo/%7Ebar", "/foo/~bar"},
+		{"/foo/%2e%2e/bar", "/bar"},
+		{"/foo/%2E/bar", "/foo/bar"},
+		{"/foo/%2Fbar", "/foo/%2Fbar"},
+		{"/a/b%2fc", "/a/b%2Fc"},
+		{"/%41%42%43", "/ABC"},
+		{"/%ab%cd", "/%AB%CD"},
+		{"/foo/%41-%5F%7E", "/foo/A-_~"},
+		{"/100%25", "/100%25"},
+	}
+
+	for _, tt := range tests {
+		if got := CleanPathRFC3986(tt.path); got != tt.want {
+			t.Errorf("CleanPathRFC3986(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+// TestCleanPathRFC3986VsNetURL 把 CleanPathRFC3986 与 net/url 的
+// 完全解码结果（u.Path）做差异测试：对于只含未保留字符百分号编码的
+// 输入，先解码再折叠 "." / ".." 段，结果必须与 CleanPath(u.Path) 完全
+// 一致。这是一个固定用例的断言测试（失败即报错，不会跳过），而不是
+// 对 EscapedPath 的模糊比较——EscapedPath 在百分号编码可以原样往返时
+// 会保留它，并不会像 CleanPathRFC3986 那样解码未保留字符或统一十六进
+// 制大小写，所以不能拿来做逐字节的差异断言。
+func TestCleanPathRFC3986VsNetURL(t *testing.T) {
+	tests := []string{
+		"/foo/%7ebar",
+		"/foo/%41%2D%5F%7E",
+		"/foo/%2e%2e/bar",
+		"/a/./b/%2e/c",
+	}
+
+	for _, p := range tests {
+		u, err := url.Parse("http://example.com" + p)
+		if err != nil {
+			t.Fatalf("url.Parse(%q) error: %v", p, err)
+		}
+
+		got := CleanPathRFC3986(p)
+		want := CleanPath(u.Path)
+		if got != want {
+			t.Errorf("CleanPathRFC3986(%q) = %q, want %q (= CleanPath(url.Path) = CleanPath(%q))", p, got, want, u.Path)
+		}
+	}
+}